@@ -0,0 +1,46 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+// apiServerCheckTimeout bounds how long the readiness check waits for the API server.
+// It must stay below the readinessProbe timeoutSeconds in config/controllers/deployment.yaml.
+const apiServerCheckTimeout = 5 * time.Second
+
+// apiServerChecker returns a readiness check that fails unless the API server
+// the manager reconciles against answers within timeout.
+// AWS reachability is deliberately not checked: credentials and region are
+// resolved per machine from its provider spec, so there is no single AWS
+// client to probe.
+func apiServerChecker(cfg *rest.Config, timeout time.Duration) healthz.Checker {
+	checkCfg := rest.CopyConfig(cfg)
+	checkCfg.Timeout = timeout
+	discoveryClient := discovery.NewDiscoveryClientForConfigOrDie(checkCfg)
+
+	return func(_ *http.Request) error {
+		if _, err := discoveryClient.ServerVersion(); err != nil {
+			return fmt.Errorf("failed to reach API server: %v", err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/rest"
+)
+
+func TestAPIServerChecker(t *testing.T) {
+	testCases := []struct {
+		testcase    string
+		handler     http.HandlerFunc
+		expectError bool
+	}{
+		{
+			testcase: "reachable",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"major":"1","minor":"18","gitVersion":"v1.18.0"}`))
+			},
+			expectError: false,
+		},
+		{
+			testcase: "server-error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			expectError: true,
+		},
+		{
+			testcase: "timeout",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(time.Second)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcase, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+
+			checker := apiServerChecker(&rest.Config{Host: server.URL}, 100*time.Millisecond)
+			err := checker(nil)
+			if tc.expectError != (err != nil) {
+				t.Errorf("expected error: %v, got: %v", tc.expectError, err)
+			}
+		})
+	}
+}
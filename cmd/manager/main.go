@@ -31,6 +31,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
@@ -40,6 +41,15 @@ func main() {
 
 	klog.InitFlags(nil)
 	watchNamespace := flag.String("namespace", "", "Namespace that the controller watches to reconcile machine-api objects. If unspecified, the controller watches for machine-api objects across all namespaces.")
+	syncPeriod := flag.Duration("sync-period", 10*time.Minute, "The minimum interval at which watched resources are reconciled.")
+	leaderElect := flag.Bool("leader-elect", false, "Start a leader election client and gain leadership before executing the main loop. Enable this when running replicated components for high availability.")
+	leaderElectResourceNamespace := flag.String("leader-elect-resource-namespace", "", "The namespace of resource object that is used for locking during leader election. If unspecified and running in cluster, defaults to the service account namespace for the controller. Required for leader-election outside of a cluster.")
+	leaderElectResourceName := flag.String("leader-elect-resource-name", "cluster-api-provider-aws-leader", "The name of resource object that is used for locking during leader election.")
+	leaderElectLeaseDuration := flag.Duration("leader-elect-lease-duration", 15*time.Second, "The duration that non-leader candidates will wait after observing a leadership renewal until attempting to acquire leadership of a led but unrenewed leader slot.")
+	leaderElectRenewDeadline := flag.Duration("leader-elect-renew-deadline", 10*time.Second, "The interval between attempts by the acting master to renew a leadership slot before it stops leading.")
+	leaderElectRetryPeriod := flag.Duration("leader-elect-retry-period", 2*time.Second, "The duration the clients should wait between attempting acquisition and renewal of a leadership.")
+	healthAddr := flag.String("health-addr", "", "The address the health probe endpoint binds to. If unspecified, health probes are not served.")
+	machineSetConcurrency := flag.Int("machineset-concurrency", 1, "The number of MachineSet objects that are allowed to sync concurrently. The Machine controller always reconciles one machine at a time.")
 	flag.Set("logtostderr", "true")
 	flag.Parse()
 
@@ -55,11 +65,17 @@ func main() {
 	}
 
 	// Setup a Manager
-	syncPeriod := 10 * time.Minute
 	opts := manager.Options{
-		SyncPeriod: &syncPeriod,
+		SyncPeriod: syncPeriod,
 		// Disable metrics serving
-		MetricsBindAddress: "0",
+		MetricsBindAddress:      "0",
+		LeaderElection:          *leaderElect,
+		LeaderElectionNamespace: *leaderElectResourceNamespace,
+		LeaderElectionID:        *leaderElectResourceName,
+		LeaseDuration:           leaderElectLeaseDuration,
+		RenewDeadline:           leaderElectRenewDeadline,
+		RetryPeriod:             leaderElectRetryPeriod,
+		HealthProbeBindAddress:  *healthAddr,
 	}
 	if *watchNamespace != "" {
 		opts.Namespace = *watchNamespace
@@ -92,10 +108,19 @@ func main() {
 	if err = (&machinesetcontroller.Reconciler{
 		Client: mgr.GetClient(),
 		Log:    ctrl.Log.WithName("controllers").WithName("MachineSet"),
-	}).SetupWithManager(mgr, controller.Options{}); err != nil {
+	}).SetupWithManager(mgr, controller.Options{MaxConcurrentReconciles: *machineSetConcurrency}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "MachineSet")
 		os.Exit(1)
 	}
+
+	if err := mgr.AddReadyzCheck("apiserver", apiServerChecker(cfg, apiServerCheckTimeout)); err != nil {
+		klog.Fatalf("Error adding readyz check: %v", err)
+	}
+
+	if err := mgr.AddHealthzCheck("ping", healthz.Ping); err != nil {
+		klog.Fatalf("Error adding healthz check: %v", err)
+	}
+
 	// Start the Cmd
 	err = mgr.Start(ctrl.SetupSignalHandler())
 	if err != nil {